@@ -0,0 +1,208 @@
+// Package codon provides the NCBI genetic code tables (1-33) as
+// codon-to-amino-acid maps, used to translate DNA/RNA into protein.
+package codon
+
+import "fmt"
+
+// Table is one NCBI genetic code table.
+type Table struct {
+	ID   int
+	Name string
+
+	aa     [64]byte // indexed by packConcrete(codon), for the 64 unambiguous codons
+	starts [64]bool // which of those codons are alternative translation start sites
+
+	// ambiguous holds the resolved amino acid for every codon
+	// containing IUPAC ambiguity codes, keyed by a 5-bits-per-base
+	// packed codon (wide enough for the 16-symbol IUPAC nucleotide
+	// alphabet, unlike the 2-bit packing used for aa/starts). It is
+	// fully populated by buildTables and never written to afterward,
+	// so concurrent calls to Translate from multiple goroutines (e.g.
+	// fasta.Pipeline workers) never race on it.
+	ambiguous map[uint16]byte
+}
+
+// concreteIndex maps an unambiguous base to its 2-bit code.
+var concreteIndex = map[byte]byte{
+	'A': 0, 'C': 1, 'G': 2, 'T': 3, 'U': 3,
+	'a': 0, 'c': 1, 'g': 2, 't': 3, 'u': 3,
+}
+
+func packConcrete(codon string) (byte, bool) {
+	var idx byte
+	for i := 0; i < 3; i++ {
+		b, ok := concreteIndex[codon[i]]
+		if !ok {
+			return 0, false
+		}
+		idx = idx<<2 | b
+	}
+	return idx, true
+}
+
+// ambiguousLetters is every IUPAC nucleotide letter, in the order
+// ambiguousIndex assigns their 5-bit codes; buildTables also uses it
+// to enumerate every packable codon when precomputing Table.ambiguous.
+var ambiguousLetters = []byte("ACGTURYSWKMBDHVN")
+
+// ambiguousIndex maps every IUPAC nucleotide letter to a 5-bit code.
+var ambiguousIndex = func() map[byte]uint16 {
+	m := make(map[byte]uint16, 32)
+	for i, b := range ambiguousLetters {
+		m[b] = uint16(i)
+		m[b+('a'-'A')] = uint16(i)
+	}
+	return m
+}()
+
+func packAmbiguous(codon []byte) (uint16, bool) {
+	var key uint16
+	for i := 0; i < 3; i++ {
+		b, ok := ambiguousIndex[codon[i]]
+		if !ok {
+			return 0, false
+		}
+		key = key<<5 | b
+	}
+	return key, true
+}
+
+// Tables holds every supported genetic code table, keyed by its NCBI
+// transl_table ID. IDs 7, 8 and 17-20 are not defined by NCBI and are
+// the only gaps in the 1-33 range.
+var Tables = buildTables()
+
+// ByID returns the genetic code table for the given NCBI transl_table
+// ID.
+func ByID(id int) (*Table, error) {
+	t, ok := Tables[id]
+	if !ok {
+		return nil, fmt.Errorf("codon: unsupported genetic code table %d", id)
+	}
+	return t, nil
+}
+
+// Translate returns the amino acid for codon under t. atStart, when
+// true, translates an alternative start codon (e.g. GTG/TTG in many
+// tables) to Met ('M') instead of its ordinary amino acid, matching
+// ribosomal initiation. Ambiguous (IUPAC) codons are resolved by
+// expanding every concrete possibility and returning 'X' unless they
+// all agree.
+func (t *Table) Translate(codon []byte, atStart bool) (byte, error) {
+	if len(codon) != 3 {
+		return 0, fmt.Errorf("codon: invalid codon %q: must be 3 bases", codon)
+	}
+
+	if idx, ok := packConcrete(string(codon)); ok {
+		if atStart && t.starts[idx] {
+			return 'M', nil
+		}
+		return t.aa[idx], nil
+	}
+
+	key, ok := packAmbiguous(codon)
+	if !ok {
+		return 0, fmt.Errorf("codon: invalid base in codon %q", codon)
+	}
+	aa, ok := t.ambiguous[key]
+	if !ok {
+		// Every codon packAmbiguous accepts is precomputed by
+		// buildTables, so this is unreachable in practice; resolve it
+		// directly rather than writing to the shared map, which Table
+		// otherwise never mutates after construction.
+		aa = t.resolveAmbiguous(codon)
+	}
+	return t.resolveStart(aa, atStart, codon), nil
+}
+
+// IsStart reports whether codon is a translation initiation site
+// under t (i.e. would translate to 'M' when atStart is true in
+// Translate), resolving IUPAC ambiguity the same way Translate does.
+func (t *Table) IsStart(codon []byte) bool {
+	if idx, ok := packConcrete(string(codon)); ok {
+		return t.starts[idx]
+	}
+	for _, variant := range expand(codon) {
+		idx, ok := packConcrete(string(variant))
+		if !ok || !t.starts[idx] {
+			return false
+		}
+	}
+	return true
+}
+
+// resolveStart applies the atStart override for an ambiguous codon,
+// only when every concrete possibility it expands to agrees on being
+// a start codon.
+func (t *Table) resolveStart(aa byte, atStart bool, codon []byte) byte {
+	if !atStart {
+		return aa
+	}
+	for _, variant := range expand(codon) {
+		idx, _ := packConcrete(string(variant))
+		if !t.starts[idx] {
+			return aa
+		}
+	}
+	return 'M'
+}
+
+// resolveAmbiguous expands codon's IUPAC ambiguity codes into every
+// concrete codon it can mean and returns the shared amino acid, or
+// 'X' if they disagree.
+func (t *Table) resolveAmbiguous(codon []byte) byte {
+	variants := expand(codon)
+
+	var aa byte
+	for i, v := range variants {
+		idx, ok := packConcrete(string(v))
+		if !ok {
+			return 'X'
+		}
+		if i == 0 {
+			aa = t.aa[idx]
+		} else if t.aa[idx] != aa {
+			return 'X'
+		}
+	}
+	return aa
+}
+
+// degenerateBaseNucl mirrors seq.DegenerateBaseMapNucl's letter sets,
+// duplicated here (rather than imported) because seq imports codon
+// for Translate/FindORFs, and codon importing seq back would be an
+// import cycle.
+var degenerateBaseNucl = map[byte][]byte{
+	'R': []byte("AG"), 'Y': []byte("CT"), 'M': []byte("AC"), 'K': []byte("GT"),
+	'S': []byte("CG"), 'W': []byte("AT"), 'H': []byte("ACT"), 'B': []byte("CGT"),
+	'V': []byte("ACG"), 'D': []byte("AGT"), 'N': []byte("ACGT"),
+	'r': []byte("ag"), 'y': []byte("ct"), 'm': []byte("ac"), 'k': []byte("gt"),
+	's': []byte("cg"), 'w': []byte("at"), 'h': []byte("act"), 'b': []byte("cgt"),
+	'v': []byte("acg"), 'd': []byte("agt"), 'n': []byte("acgt"),
+}
+
+// expand enumerates every concrete codon a (possibly ambiguous) codon
+// can mean.
+func expand(codon []byte) [][]byte {
+	letters := make([][]byte, 3)
+	for i, b := range codon {
+		if ls, ok := degenerateBaseNucl[b]; ok {
+			letters[i] = ls
+		} else {
+			letters[i] = []byte{b}
+		}
+	}
+
+	variants := [][]byte{{}}
+	for _, ls := range letters {
+		var next [][]byte
+		for _, v := range variants {
+			for _, l := range ls {
+				nv := append(append([]byte(nil), v...), l)
+				next = append(next, nv)
+			}
+		}
+		variants = next
+	}
+	return variants
+}
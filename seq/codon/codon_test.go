@@ -0,0 +1,94 @@
+package codon
+
+import "testing"
+
+func TestTranslateStandard(t *testing.T) {
+	table, err := ByID(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		codon string
+		want  byte
+	}{
+		{"ATG", 'M'},
+		{"TAA", '*'},
+		{"GGT", 'G'},
+	}
+	for _, c := range cases {
+		aa, err := table.Translate([]byte(c.codon), false)
+		if err != nil {
+			t.Fatalf("Translate(%s): %s", c.codon, err)
+		}
+		if aa != c.want {
+			t.Errorf("Translate(%s) = %q, want %q", c.codon, aa, c.want)
+		}
+	}
+}
+
+func TestTranslateAmbiguous(t *testing.T) {
+	table, _ := ByID(1)
+
+	// GGN: GGT/GGC/GGA/GGG all translate to Gly, so the ambiguous
+	// codon should resolve unambiguously.
+	aa, err := table.Translate([]byte("GGN"), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if aa != 'G' {
+		t.Errorf("Translate(GGN) = %q, want 'G'", aa)
+	}
+
+	// YTA: CTA=Leu, TTA=Leu too, so this should also agree (not X).
+	aa, err = table.Translate([]byte("YTA"), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if aa != 'L' {
+		t.Errorf("Translate(YTA) = %q, want 'L'", aa)
+	}
+
+	// RGA: AGA=Arg, GGA=Gly, these disagree so must be 'X'.
+	aa, err = table.Translate([]byte("RGA"), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if aa != 'X' {
+		t.Errorf("Translate(RGA) = %q, want 'X'", aa)
+	}
+}
+
+func TestAlternativeStart(t *testing.T) {
+	// Table 11 (Bacterial) accepts GTG as an alternative start codon.
+	table, err := ByID(11)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !table.IsStart([]byte("GTG")) {
+		t.Error("GTG should be a start codon under table 11")
+	}
+	aa, err := table.Translate([]byte("GTG"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if aa != 'M' {
+		t.Errorf("Translate(GTG, atStart=true) = %q, want 'M'", aa)
+	}
+	aa, err = table.Translate([]byte("GTG"), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if aa != 'V' {
+		t.Errorf("Translate(GTG, atStart=false) = %q, want 'V'", aa)
+	}
+}
+
+func TestByIDUnsupported(t *testing.T) {
+	if _, err := ByID(7); err == nil {
+		t.Error("expected an error for unsupported table 7")
+	}
+	if _, err := ByID(15); err != nil {
+		t.Errorf("table 15 should be supported: %s", err)
+	}
+}
@@ -0,0 +1,146 @@
+package codon
+
+// standardCodons is NCBI genetic code table 1 (the Standard Code).
+// Every other table is expressed as a set of differences from it,
+// the same way NCBI documents them.
+var standardCodons = map[string]byte{
+	"TTT": 'F', "TTC": 'F', "TTA": 'L', "TTG": 'L',
+	"TCT": 'S', "TCC": 'S', "TCA": 'S', "TCG": 'S',
+	"TAT": 'Y', "TAC": 'Y', "TAA": '*', "TAG": '*',
+	"TGT": 'C', "TGC": 'C', "TGA": '*', "TGG": 'W',
+	"CTT": 'L', "CTC": 'L', "CTA": 'L', "CTG": 'L',
+	"CCT": 'P', "CCC": 'P', "CCA": 'P', "CCG": 'P',
+	"CAT": 'H', "CAC": 'H', "CAA": 'Q', "CAG": 'Q',
+	"CGT": 'R', "CGC": 'R', "CGA": 'R', "CGG": 'R',
+	"ATT": 'I', "ATC": 'I', "ATA": 'I', "ATG": 'M',
+	"ACT": 'T', "ACC": 'T', "ACA": 'T', "ACG": 'T',
+	"AAT": 'N', "AAC": 'N', "AAA": 'K', "AAG": 'K',
+	"AGT": 'S', "AGC": 'S', "AGA": 'R', "AGG": 'R',
+	"GTT": 'V', "GTC": 'V', "GTA": 'V', "GTG": 'V',
+	"GCT": 'A', "GCC": 'A', "GCA": 'A', "GCG": 'A',
+	"GAT": 'D', "GAC": 'D', "GAA": 'E', "GAG": 'E',
+	"GGT": 'G', "GGC": 'G', "GGA": 'G', "GGG": 'G',
+}
+
+// tableDef describes one NCBI genetic code table as a diff against
+// standardCodons, plus its own list of alternative start codons.
+type tableDef struct {
+	id     int
+	name   string
+	diffs  map[string]byte
+	starts []string
+}
+
+var tableDefs = []tableDef{
+	{1, "Standard", nil, []string{"ATG"}},
+	{2, "Vertebrate Mitochondrial", map[string]byte{
+		"AGA": '*', "AGG": '*', "ATA": 'M', "TGA": 'W',
+	}, []string{"ATT", "ATC", "ATA", "ATG", "GTG"}},
+	{3, "Yeast Mitochondrial", map[string]byte{
+		"ATA": 'M', "CTT": 'T', "CTC": 'T', "CTA": 'T', "CTG": 'T', "TGA": 'W',
+	}, []string{"ATA", "ATG", "GTG"}},
+	{4, "Mold, Protozoan, and Coelenterate Mitochondrial; Mycoplasma; Spiroplasma", map[string]byte{
+		"TGA": 'W',
+	}, []string{"TTA", "TTG", "CTG", "ATT", "ATC", "ATA", "ATG", "GTG"}},
+	{5, "Invertebrate Mitochondrial", map[string]byte{
+		"AGA": 'S', "AGG": 'S', "ATA": 'M', "TGA": 'W',
+	}, []string{"TTG", "ATT", "ATC", "ATA", "ATG", "GTG"}},
+	{6, "Ciliate, Dasycladacean and Hexamita Nuclear", map[string]byte{
+		"TAA": 'Q', "TAG": 'Q',
+	}, []string{"ATG"}},
+	{9, "Echinoderm and Flatworm Mitochondrial", map[string]byte{
+		"AAA": 'N', "AGA": 'S', "AGG": 'S', "TGA": 'W',
+	}, []string{"ATG", "GTG"}},
+	{10, "Euplotid Nuclear", map[string]byte{
+		"TGA": 'C',
+	}, []string{"ATG"}},
+	{11, "Bacterial, Archaeal and Plant Plastid", nil,
+		[]string{"TTG", "CTG", "ATT", "ATC", "ATA", "ATG", "GTG"}},
+	{12, "Alternative Yeast Nuclear", map[string]byte{
+		"CTG": 'S',
+	}, []string{"CTG", "ATG"}},
+	{13, "Ascidian Mitochondrial", map[string]byte{
+		"AGA": 'G', "AGG": 'G', "ATA": 'M', "TGA": 'W',
+	}, []string{"TTG", "ATA", "ATG", "GTG"}},
+	{14, "Alternative Flatworm Mitochondrial", map[string]byte{
+		"AAA": 'N', "AGA": 'S', "AGG": 'S', "TAA": 'Y', "TGA": 'W',
+	}, []string{"ATG"}},
+	{15, "Blepharisma Macronuclear", map[string]byte{
+		"TAG": 'Q',
+	}, []string{"ATG"}},
+	{16, "Chlorophycean Mitochondrial", map[string]byte{
+		"TAG": 'L',
+	}, []string{"ATG"}},
+	{21, "Trematode Mitochondrial", map[string]byte{
+		"TGA": 'W', "ATA": 'M', "AGA": 'S', "AGG": 'S', "AAA": 'N',
+	}, []string{"ATG", "GTG"}},
+	{22, "Scenedesmus obliquus Mitochondrial", map[string]byte{
+		"TCA": '*', "TAG": 'L',
+	}, []string{"ATG"}},
+	{23, "Thraustochytrium Mitochondrial", map[string]byte{
+		"TTA": '*',
+	}, []string{"ATT", "GTG", "ATG"}},
+	{24, "Pterobranchia Mitochondrial", map[string]byte{
+		"AGA": 'S', "AGG": 'K', "TGA": 'W',
+	}, []string{"TTG", "CTG", "ATG", "GTG"}},
+	{25, "Candidate Division SR1 and Gracilibacteria", map[string]byte{
+		"TGA": 'G',
+	}, []string{"TTG", "ATG", "GTG"}},
+	{26, "Pachysolen tannophilus Nuclear", map[string]byte{
+		"CTG": 'A',
+	}, []string{"CTG", "ATG"}},
+	{27, "Karyorelict Nuclear", map[string]byte{
+		"TAA": 'Q', "TAG": 'Q', "TGA": 'W',
+	}, []string{"ATG"}},
+	{28, "Condylostoma Nuclear", map[string]byte{
+		"TAA": 'Q', "TAG": 'Q', "TGA": 'W',
+	}, []string{"ATG"}},
+	{29, "Mesodinium Nuclear", map[string]byte{
+		"TAA": 'Y', "TAG": 'Y',
+	}, []string{"ATG"}},
+	{30, "Peritrich Nuclear", map[string]byte{
+		"TAA": 'E', "TAG": 'E',
+	}, []string{"ATG"}},
+	{31, "Blastocrithidia Nuclear", map[string]byte{
+		"TAA": 'E', "TAG": 'E', "TGA": 'W',
+	}, []string{"ATG"}},
+	{32, "Balanophoraceae Plastid", map[string]byte{
+		"TAG": 'W',
+	}, []string{"ATG"}},
+	{33, "Cephalodiscidae Mitochondrial", map[string]byte{
+		"AGA": 'S', "AGG": 'K', "TAA": 'Y', "TGA": 'W',
+	}, []string{"ATG", "GTG"}},
+}
+
+func buildTables() map[int]*Table {
+	tables := make(map[int]*Table, len(tableDefs))
+	for _, def := range tableDefs {
+		t := &Table{ID: def.id, Name: def.name, ambiguous: make(map[uint16]byte)}
+
+		for codon, aa := range standardCodons {
+			idx, _ := packConcrete(codon)
+			t.aa[idx] = aa
+		}
+		for codon, aa := range def.diffs {
+			idx, _ := packConcrete(codon)
+			t.aa[idx] = aa
+		}
+		for _, codon := range def.starts {
+			idx, _ := packConcrete(codon)
+			t.starts[idx] = true
+		}
+
+		for _, a := range ambiguousLetters {
+			for _, b := range ambiguousLetters {
+				for _, c := range ambiguousLetters {
+					codon := []byte{a, b, c}
+					key, _ := packAmbiguous(codon)
+					t.ambiguous[key] = t.resolveAmbiguous(codon)
+				}
+			}
+		}
+
+		tables[def.id] = t
+	}
+	return tables
+}
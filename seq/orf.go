@@ -0,0 +1,116 @@
+package seq
+
+import "github.com/shenwei356/bio/seq/codon"
+
+// ORF is one open reading frame found by FindORFs.
+type ORF struct {
+	Start   int // 1-based, inclusive, on the Plus-strand numbering, consistent with SubSeq
+	End     int // 1-based, inclusive
+	Frame   int // 0, 1 or 2
+	Strand  Strand
+	Protein *Seq
+}
+
+// FindORFs scans the requested strands of seq, three frames each, for
+// open reading frames: a run from a start codon to the next in-frame
+// stop codon that is at least minLen codons long. tables is tried in
+// order per frame; the first table an in-frame start codon is valid
+// under is used for that ORF. Genetic code table IDs in tables that
+// codon.ByID doesn't recognize are silently skipped.
+func (seq *Seq) FindORFs(tables []int, minLen int, strands Strand) []ORF {
+	var orfs []ORF
+
+	if strands&Plus != 0 {
+		orfs = append(orfs, findORFs(seq, Plus, len(seq.Seq), tables, minLen)...)
+	}
+	if strands&Minus != 0 {
+		orfs = append(orfs, findORFs(seq.RevCom(), Minus, len(seq.Seq), tables, minLen)...)
+	}
+
+	return orfs
+}
+
+func findORFs(s *Seq, strand Strand, seqLen int, tableIDs []int, minLen int) []ORF {
+	var tables []*codon.Table
+	for _, id := range tableIDs {
+		t, err := codon.ByID(id)
+		if err != nil {
+			continue
+		}
+		tables = append(tables, t)
+	}
+
+	var orfs []ORF
+	for frame := 0; frame < 3; frame++ {
+		orfs = append(orfs, findORFsInFrame(s, tables, frame, strand, seqLen, minLen)...)
+	}
+	return orfs
+}
+
+// findORFsInFrame scans one frame for ORFs. The table choice is made
+// per start codon, not per frame: tables is tried in order and the
+// first one an in-frame codon is a valid start under opens the ORF,
+// which is then translated and closed out under that same table, so
+// a start/stop pair valid under several tables is reported once under
+// the first matching one instead of once per table.
+func findORFsInFrame(s *Seq, tables []*codon.Table, frame int, strand Strand, seqLen int, minLen int) []ORF {
+	var src []byte
+	if frame < len(s.Seq) {
+		src = s.Seq[frame:]
+	}
+
+	var orfs []ORF
+	var table *codon.Table // table the open ORF at start was found under
+	start := -1            // position in src of an open ORF's start codon, or -1
+
+	for i := 0; i+3 <= len(src); i += 3 {
+		c := src[i : i+3]
+
+		if start == -1 {
+			for _, t := range tables {
+				if t.IsStart(c) {
+					start, table = i, t
+					break
+				}
+			}
+			continue
+		}
+
+		aa, err := table.Translate(c, false)
+		if err != nil {
+			start = -1
+			continue
+		}
+		if aa == '*' {
+			if (i-start)/3 >= minLen {
+				orfs = append(orfs, buildORF(s, table, frame, start, i, strand, seqLen))
+			}
+			start = -1
+		}
+	}
+
+	return orfs
+}
+
+// buildORF packages the ORF spanning src[start:stop] (stop being the
+// stop codon's position, exclusive of it) of the strand scanned into
+// Plus-strand coordinates.
+func buildORF(s *Seq, t *codon.Table, frame, start, stop int, strand Strand, seqLen int) ORF {
+	src := s.Seq[frame:]
+
+	protein := make([]byte, 0, (stop-start)/3)
+	for i := start; i < stop; i += 3 {
+		aa, _ := t.Translate(src[i:i+3], i == start)
+		protein = append(protein, aa)
+	}
+	proteinSeq, _ := NewSeqWithoutValidate(Protein, protein)
+
+	absStart := frame + start + 1 // 1-based
+	absEnd := frame + stop + 3    // stop codon's last base, 1-based
+	startPos, endPos := absStart, absEnd
+	if strand == Minus {
+		startPos, endPos = seqLen-absEnd+1, seqLen-absStart+1
+	}
+
+	return ORF{Start: startPos, End: endPos, Frame: frame, Strand: strand, Protein: proteinSeq}
+}
@@ -0,0 +1,151 @@
+package seq
+
+import (
+	"fmt"
+	"math"
+)
+
+// QualEncoding identifies a FASTQ quality encoding scheme, i.e. the
+// ASCII offset and score range used to pack a Phred or Solexa quality
+// score into a single byte.
+type QualEncoding int
+
+// Supported quality encodings, ordered roughly by how common they are
+// in modern sequencing data.
+const (
+	// Sanger is Phred+33, Phred score range 0-93, used by Illumina
+	// 1.8+ and essentially all current data.
+	Sanger QualEncoding = iota
+	// Illumina1_3 is Phred+64, Phred score range 0-62, used by
+	// Illumina pipeline versions 1.3 through 1.7.
+	Illumina1_3
+	// Solexa is Solexa+64, Solexa score range -5-62, used by the
+	// original Solexa/early Illumina pipelines. Solexa scores use a
+	// different log-odds formula than Phred scores below Q~13.
+	Solexa
+)
+
+// qualOffset is the ASCII value representing a quality score of 0 (or,
+// for Solexa, its zero point; actual Solexa scores can be negative).
+var qualOffset = map[QualEncoding]int{
+	Sanger:      33,
+	Illumina1_3: 64,
+	Solexa:      64,
+}
+
+func (e QualEncoding) String() string {
+	switch e {
+	case Sanger:
+		return "Sanger (Phred+33)"
+	case Illumina1_3:
+		return "Illumina-1.3 (Phred+64)"
+	case Solexa:
+		return "Solexa (Solexa+64)"
+	default:
+		return "unknown"
+	}
+}
+
+// GuessQualEncoding inspects the lowest byte of qual and returns an
+// encoding consistent with it. Because the encodings' byte ranges
+// overlap, this is a heuristic: a lowest byte below 59 (which only
+// Sanger uses) gives Sanger; 59-65 gives Solexa (59-63 is below the
+// Phred+64 floor, and 64-65 lies in the Illumina-1.3/Solexa overlap);
+// anything higher gives Illumina-1.3. Sanger data whose bytes are all
+// 59 or above is not reported as Sanger.
+func GuessQualEncoding(qual []byte) QualEncoding {
+	if len(qual) == 0 {
+		return Sanger
+	}
+
+	min, max := qual[0], qual[0]
+	for _, b := range qual[1:] {
+		if b < min {
+			min = b
+		}
+		if b > max {
+			max = b
+		}
+	}
+
+	switch {
+	case min < 59:
+		return Sanger
+	case min < 66:
+		return Solexa
+	default:
+		return Illumina1_3
+	}
+}
+
+// QualEncoding guesses the quality encoding of seq.Qual, see
+// GuessQualEncoding.
+func (seq *Seq) QualEncoding() QualEncoding {
+	return GuessQualEncoding(seq.Qual)
+}
+
+// ParseQual decodes seq.Qual under the given encoding into
+// seq.QualValue, one Phred-scaled score per base. For Solexa input,
+// scores are converted to the equivalent Phred score so QualValue is
+// always Phred-scaled regardless of source encoding.
+func (seq *Seq) ParseQual(encoding QualEncoding) error {
+	values := make([]int, len(seq.Qual))
+	offset := qualOffset[encoding]
+	for i, b := range seq.Qual {
+		v := int(b) - offset
+		if encoding == Solexa {
+			// Check the raw Solexa score against its valid floor before
+			// converting: solexaToPhred clamps anything below -5 to 0,
+			// which would otherwise silently swallow an out-of-range
+			// byte instead of rejecting it.
+			if v < -5 {
+				return fmt.Errorf("seq: invalid quality byte %q for encoding %s", b, encoding)
+			}
+			v = solexaToPhred(v)
+		} else if v < 0 {
+			return fmt.Errorf("seq: invalid quality byte %q for encoding %s", b, encoding)
+		}
+		values[i] = v
+	}
+	seq.QualValue = values
+	return nil
+}
+
+// ConvertQual re-encodes seq.Qual from one encoding to another, also
+// refreshing seq.QualValue with the Phred-scaled scores.
+func (seq *Seq) ConvertQual(from, to QualEncoding) error {
+	if err := seq.ParseQual(from); err != nil {
+		return err
+	}
+
+	toOffset := qualOffset[to]
+	q := make([]byte, len(seq.QualValue))
+	for i, v := range seq.QualValue {
+		score := v
+		if to == Solexa {
+			score = phredToSolexa(v)
+		}
+		q[i] = byte(score + toOffset)
+	}
+	seq.Qual = q
+	return nil
+}
+
+// solexaToPhred converts a Solexa-scaled quality score to the
+// equivalent Phred-scaled score, following the standard log-odds
+// relationship between the two scales.
+func solexaToPhred(solexa int) int {
+	if solexa < -5 {
+		return 0
+	}
+	return int(10*math.Log10(1+math.Pow(10, float64(solexa)/10)) + 0.5)
+}
+
+// phredToSolexa converts a Phred-scaled quality score to the
+// equivalent Solexa-scaled score.
+func phredToSolexa(phred int) int {
+	if phred == 0 {
+		return -5
+	}
+	return int(10*math.Log10(math.Pow(10, float64(phred)/10)-1) + 0.5)
+}
@@ -0,0 +1,94 @@
+package seq
+
+import "testing"
+
+func TestGuessQualEncoding(t *testing.T) {
+	cases := []struct {
+		qual []byte
+		want QualEncoding
+	}{
+		{[]byte{}, Sanger},
+		{[]byte("!\"#$%"), Sanger},    // bytes below 59: only Sanger uses this range
+		{[]byte(";<=>?"), Solexa},     // 59-63: Solexa -5 to -1, below Phred+64's floor
+		{[]byte("@ABCDE"), Solexa},    // 64-65, within the Illumina-1.3/Solexa overlap
+		{[]byte("hijk"), Illumina1_3}, // well above the overlap
+	}
+
+	for _, c := range cases {
+		if got := GuessQualEncoding(c.qual); got != c.want {
+			t.Errorf("GuessQualEncoding(%q) = %s, want %s", c.qual, got, c.want)
+		}
+	}
+}
+
+func TestParseQualSanger(t *testing.T) {
+	s, err := NewSeqWithoutValidate(DNA, []byte("ACGT"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Qual = []byte("!+5?") // Phred 0, 10, 20, 30
+
+	if err := s.ParseQual(Sanger); err != nil {
+		t.Fatal(err)
+	}
+	want := []int{0, 10, 20, 30}
+	for i, v := range want {
+		if s.QualValue[i] != v {
+			t.Errorf("QualValue[%d] = %d, want %d", i, s.QualValue[i], v)
+		}
+	}
+}
+
+func TestParseQualRejectsByteBelowEncodingRange(t *testing.T) {
+	s, err := NewSeqWithoutValidate(DNA, []byte("A"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s.Qual = []byte{' '} // 32, below Sanger's 33 offset
+	if err := s.ParseQual(Sanger); err == nil {
+		t.Error("ParseQual(Sanger) on a byte below the encoding's offset = nil error, want one")
+	}
+
+	s.Qual = []byte{64 - 6} // offset 64, raw score -6, below Solexa's -5 floor
+	if err := s.ParseQual(Solexa); err == nil {
+		t.Error("ParseQual(Solexa) on a byte below the -5 Solexa floor = nil error, want one")
+	}
+}
+
+func TestConvertQual(t *testing.T) {
+	s, err := NewSeqWithoutValidate(DNA, []byte("ACGT"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Qual = []byte("BKU_") // Illumina-1.3 (Phred+64): 2, 11, 21, 31
+
+	if err := s.ConvertQual(Illumina1_3, Sanger); err != nil {
+		t.Fatal(err)
+	}
+	want := "#,6@" // Sanger (Phred+33) for the same scores
+	if string(s.Qual) != want {
+		t.Errorf("ConvertQual(Illumina1.3, Sanger) = %q, want %q", s.Qual, want)
+	}
+}
+
+func TestSolexaPhredRoundTrip(t *testing.T) {
+	// Above Q~13 the two scales agree closely enough to round-trip
+	// exactly; below that the log-odds correction makes them diverge,
+	// which is the entire reason Solexa needs its own conversion.
+	for _, phred := range []int{20, 30, 40, 50} {
+		solexa := phredToSolexa(phred)
+		if got := solexaToPhred(solexa); got != phred {
+			t.Errorf("solexaToPhred(phredToSolexa(%d)) = %d, want %d", phred, got, phred)
+		}
+	}
+}
+
+func TestSolexaToPhredFloor(t *testing.T) {
+	if got := solexaToPhred(-5); got != 0 {
+		t.Errorf("solexaToPhred(-5) = %d, want 0", got)
+	}
+	if got := solexaToPhred(-10); got != 0 {
+		t.Errorf("solexaToPhred(-10) = %d, want 0 (clamped)", got)
+	}
+}
@@ -0,0 +1,190 @@
+// Package search provides a degenerate-base aware, multi-pattern
+// Aho-Corasick matcher for scanning *seq.Seq against IUPAC patterns
+// (primer/motif/adapter search) in O(n + matches), which a
+// seq.Degenerate2Regexp-built regexp cannot do efficiently for many
+// patterns at once.
+package search
+
+import (
+	"bytes"
+
+	"github.com/shenwei356/bio/seq"
+)
+
+// node is one state of the Aho-Corasick automaton.
+type node struct {
+	children map[byte]*node
+	fail     *node
+	output   *node // nearest terminal node on the failure chain, excluding the node itself
+	patterns []int // IDs of patterns ending at this node
+}
+
+func newNode() *node {
+	return &node{children: make(map[byte]*node)}
+}
+
+// Matcher is a multi-pattern Aho-Corasick automaton whose patterns
+// may contain IUPAC degenerate codes.
+type Matcher struct {
+	root     *node
+	patterns [][]byte // original, upper-cased patterns, indexed by pattern ID
+	protein  bool
+}
+
+// NewMatcher builds a Matcher for patterns, which may contain IUPAC
+// degenerate codes (see seq.DegenerateBaseMapNucl / DegenerateBaseMapProt).
+// protein selects the amino acid degenerate code table; otherwise the
+// nucleotide table is used. Matching is case-insensitive: patterns and
+// scanned sequences are folded to uppercase during construction and
+// search.
+func NewMatcher(patterns [][]byte, protein bool) *Matcher {
+	m := &Matcher{root: newNode(), protein: protein}
+	for _, p := range patterns {
+		m.AddPattern(p)
+	}
+	m.build()
+	return m
+}
+
+// degenerateMap returns the IUPAC code table to expand pattern letters
+// with, folded to uppercase keys and values.
+func (m *Matcher) degenerateMap() map[byte][]byte {
+	var src map[byte]string
+	if m.protein {
+		src = seq.DegenerateBaseMapProt
+	} else {
+		src = seq.DegenerateBaseMapNucl
+	}
+
+	out := make(map[byte][]byte, len(src))
+	for k, v := range src {
+		k = bytes.ToUpper([]byte{k})[0]
+		letters := bytes.ToUpper([]byte(v))
+		letters = bytes.Trim(letters, "[]")
+		out[k] = letters
+	}
+	return out
+}
+
+func (m *Matcher) expand(b byte, table map[byte][]byte) []byte {
+	if letters, ok := table[b]; ok {
+		return letters
+	}
+	return []byte{b}
+}
+
+// AddPattern registers one more pattern after construction-time setup,
+// returning its pattern ID. Patterns added this way must be followed
+// by a call to Build before Search is used.
+func (m *Matcher) AddPattern(pattern []byte) int {
+	id := len(m.patterns)
+	m.patterns = append(m.patterns, bytes.ToUpper(pattern))
+	m.insert(bytes.ToUpper(pattern), id, m.degenerateMap())
+	return id
+}
+
+// insert adds pattern's trie path(s), tagging every node the pattern
+// can end at with id. A degenerate position advances a set of
+// "frontier" states rather than a single node: every letter the
+// position can stand for follows its own edge, continuing into the
+// existing child for that letter or a new node of its own. No node is
+// shared between letters, so each node stands for one concrete prefix
+// and no pattern's suffix is merged into an unrelated pattern's state
+// (which would produce false matches). The trie grows with the number
+// of concrete sequences a degenerate pattern expands to.
+func (m *Matcher) insert(pattern []byte, id int, table map[byte][]byte) {
+	frontier := []*node{m.root}
+	for _, b := range pattern {
+		frontier = m.advance(frontier, m.expand(b, table))
+	}
+	for _, n := range frontier {
+		tag(n, id)
+	}
+}
+
+// tag appends id to n's patterns unless it's already there. Separate
+// insert calls for the same pattern ID can reach the same node, as
+// NewMatcherWithMismatches makes when a Hamming-neighborhood
+// variant coincides with the original pattern's own degenerate
+// expansion — and Search must not report the same occurrence twice.
+func tag(n *node, id int) {
+	for _, existing := range n.patterns {
+		if existing == id {
+			return
+		}
+	}
+	n.patterns = append(n.patterns, id)
+}
+
+// advance steps every node in frontier over every letter in letters,
+// returning the deduplicated set of resulting nodes.
+func (m *Matcher) advance(frontier []*node, letters []byte) []*node {
+	seen := make(map[*node]bool)
+	var next []*node
+
+	for _, n := range frontier {
+		for _, l := range letters {
+			if c, ok := n.children[l]; ok {
+				if !seen[c] {
+					seen[c] = true
+					next = append(next, c)
+				}
+				continue
+			}
+			c := newNode()
+			n.children[l] = c
+			seen[c] = true
+			next = append(next, c)
+		}
+	}
+
+	return next
+}
+
+// Build (re)computes failure and output links via BFS. Call it after
+// adding patterns with AddPattern; NewMatcher calls it automatically.
+func (m *Matcher) Build() {
+	m.build()
+}
+
+func (m *Matcher) build() {
+	m.root.fail = m.root
+
+	queue := make([]*node, 0, len(m.root.children))
+	for _, child := range m.root.children {
+		child.fail = m.root
+		queue = append(queue, child)
+	}
+
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+
+		for b, child := range n.children {
+			queue = append(queue, child)
+
+			child.fail = nil // drop any link left by an earlier build
+			fail := n.fail
+			for fail != m.root {
+				if c, ok := fail.children[b]; ok {
+					child.fail = c
+					break
+				}
+				fail = fail.fail
+			}
+			if child.fail == nil {
+				if c, ok := m.root.children[b]; ok && c != child {
+					child.fail = c
+				} else {
+					child.fail = m.root
+				}
+			}
+		}
+
+		if len(n.fail.patterns) > 0 {
+			n.output = n.fail
+		} else {
+			n.output = n.fail.output
+		}
+	}
+}
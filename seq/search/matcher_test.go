@@ -0,0 +1,56 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/shenwei356/bio/seq"
+)
+
+func TestMatcherDegenerateDoesNotMergeUnrelatedPatterns(t *testing.T) {
+	m := NewMatcher([][]byte{[]byte("AT"), []byte("NG")}, false)
+
+	s, err := seq.NewSeqWithoutValidate(seq.DNA, []byte("CT"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var matches []Match
+	for match := range m.Search(s, false) {
+		matches = append(matches, match)
+	}
+
+	for _, match := range matches {
+		if match.PatternID == 0 {
+			t.Errorf("Search(%q) falsely matched pattern 0 (%q): %+v", s.Seq, "AT", match)
+		}
+	}
+}
+
+func TestMatcherDegenerateStillMatchesBothPatterns(t *testing.T) {
+	m := NewMatcher([][]byte{[]byte("AT"), []byte("NG")}, false)
+
+	cases := []struct {
+		text    string
+		pattern int
+	}{
+		{"AT", 0},
+		{"AG", 1},
+		{"CG", 1},
+	}
+	for _, c := range cases {
+		s, err := seq.NewSeqWithoutValidate(seq.DNA, []byte(c.text))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		found := false
+		for match := range m.Search(s, false) {
+			if match.PatternID == c.pattern {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("Search(%q) did not match pattern %d", c.text, c.pattern)
+		}
+	}
+}
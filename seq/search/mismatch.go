@@ -0,0 +1,72 @@
+package search
+
+import "bytes"
+
+// NewMatcherWithMismatches builds a Matcher like NewMatcher, but also
+// matches any variant of a pattern within maxMismatches substitutions
+// of it, by expanding each pattern into its mismatch neighborhood at
+// build time and inserting every variant under the original pattern's
+// ID. Variants are generated over the concrete (non-degenerate)
+// alphabet, so this composes with IUPAC codes already present in
+// patterns. Cost is combinatorial in maxMismatches and pattern length;
+// this mode is intended for small maxMismatches (1-2) and short
+// patterns (primers/adapters), not long motifs.
+func NewMatcherWithMismatches(patterns [][]byte, protein bool, maxMismatches int) *Matcher {
+	m := &Matcher{root: newNode(), protein: protein}
+	table := m.degenerateMap()
+	alphabet := concreteAlphabet(protein)
+
+	for _, p := range patterns {
+		id := len(m.patterns)
+		up := bytes.ToUpper(p)
+		m.patterns = append(m.patterns, up)
+		for _, variant := range neighborhood(up, maxMismatches, alphabet) {
+			m.insert(variant, id, table)
+		}
+	}
+
+	m.build()
+	return m
+}
+
+func concreteAlphabet(protein bool) []byte {
+	if protein {
+		return []byte("ACDEFGHIKLMNPQRSTVWY")
+	}
+	return []byte("ACGT")
+}
+
+// neighborhood returns pattern and every sequence within Hamming
+// distance k of it (substitutions only, no indels), drawn from
+// alphabet, without duplicates.
+func neighborhood(pattern []byte, k int, alphabet []byte) [][]byte {
+	variants := [][]byte{append([]byte(nil), pattern...)}
+	seen := map[string]bool{string(pattern): true}
+	frontier := variants
+
+	for d := 0; d < k; d++ {
+		var next [][]byte
+		for _, v := range frontier {
+			for i := range v {
+				orig := v[i]
+				for _, a := range alphabet {
+					if a == orig {
+						continue
+					}
+					nv := append([]byte(nil), v...)
+					nv[i] = a
+					key := string(nv)
+					if seen[key] {
+						continue
+					}
+					seen[key] = true
+					variants = append(variants, nv)
+					next = append(next, nv)
+				}
+			}
+		}
+		frontier = next
+	}
+
+	return variants
+}
@@ -0,0 +1,30 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/shenwei356/bio/seq"
+)
+
+func TestMatcherWithMismatchesDoesNotDoubleCountDegenerateOverlap(t *testing.T) {
+	// "NA" at k=1 generates the Hamming-neighborhood variant "AA",
+	// which converges on the exact trie node the original "NA"
+	// pattern's own degenerate ('N' -> A/C/G/T) expansion already
+	// created and tagged with id 0.
+	m := NewMatcherWithMismatches([][]byte{[]byte("NA")}, false, 1)
+
+	s, err := seq.NewSeqWithoutValidate(seq.DNA, []byte("AA"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var matches int
+	for match := range m.Search(s, false) {
+		if match.PatternID == 0 {
+			matches++
+		}
+	}
+	if matches != 1 {
+		t.Errorf("Search(%q) reported pattern 0 %d times, want exactly 1", s.Seq, matches)
+	}
+}
@@ -0,0 +1,87 @@
+package search
+
+import (
+	"bytes"
+
+	"github.com/shenwei356/bio/seq"
+)
+
+// Strand identifies which strand a Match was found on.
+type Strand byte
+
+// The two strands a Matcher can scan.
+const (
+	Plus  Strand = '+'
+	Minus Strand = '-'
+)
+
+func (s Strand) String() string {
+	return string(s)
+}
+
+// Match reports one pattern occurrence. Start and End are 1-based and
+// inclusive, using the same coordinate convention as seq.Seq.SubSeq,
+// and are always relative to the Plus-strand sequence regardless of
+// which strand the match was found on.
+type Match struct {
+	PatternID int
+	Start     int
+	End       int
+	Strand    Strand
+}
+
+// Search scans s for every registered pattern and streams matches on
+// Match as they are found, in position order within each strand. If
+// bothStrands is true, s.RevCom() is also scanned and its match
+// coordinates are translated back onto the Plus-strand numbering.
+func (m *Matcher) Search(s *seq.Seq, bothStrands bool) <-chan Match {
+	out := make(chan Match)
+
+	go func() {
+		defer close(out)
+		m.scan(s.Seq, Plus, len(s.Seq), out)
+		if bothStrands {
+			rc := s.RevCom()
+			m.scan(rc.Seq, Minus, len(s.Seq), out)
+		}
+	}()
+
+	return out
+}
+
+func (m *Matcher) scan(text []byte, strand Strand, seqLen int, out chan<- Match) {
+	text = bytes.ToUpper(text)
+	n := m.root
+
+	emit := func(id, end int) {
+		start := end - len(m.patterns[id]) + 1
+		if strand == Minus {
+			out <- Match{id, seqLen - end + 1, seqLen - start + 1, strand}
+		} else {
+			out <- Match{id, start, end, strand}
+		}
+	}
+
+	for i, b := range text {
+		for {
+			if c, ok := n.children[b]; ok {
+				n = c
+				break
+			}
+			if n == m.root {
+				break
+			}
+			n = n.fail
+		}
+
+		end := i + 1
+		for _, id := range n.patterns {
+			emit(id, end)
+		}
+		for term := n.output; term != nil; term = term.output {
+			for _, id := range term.patterns {
+				emit(id, end)
+			}
+		}
+	}
+}
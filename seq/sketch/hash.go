@@ -0,0 +1,105 @@
+// Package sketch computes (w, k) minimizers and open/closed syncmers
+// over *seq.Seq, the seeding primitives behind tools like minimap2,
+// using a canonical 2-bit encoding and an ntHash-style rolling hash so
+// that sliding the window by one base costs O(1) rather than
+// rehashing the whole k-mer.
+package sketch
+
+import "math/bits"
+
+// Strand records which strand a canonical hash was computed from.
+type Strand byte
+
+// The two strands a canonical k-mer hash can come from.
+const (
+	Plus  Strand = '+'
+	Minus Strand = '-'
+)
+
+// code2bit maps A/C/G/T (either case) to a 2-bit value; any other
+// byte (N, IUPAC ambiguity codes, gaps, ...) maps to -1 and breaks
+// the current window, matching how minimizer/syncmer tools treat
+// non-ACGT bases.
+var code2bit [256]int8
+
+func init() {
+	for i := range code2bit {
+		code2bit[i] = -1
+	}
+	code2bit['A'], code2bit['a'] = 0, 0
+	code2bit['C'], code2bit['c'] = 1, 1
+	code2bit['G'], code2bit['g'] = 2, 2
+	code2bit['T'], code2bit['t'] = 3, 3
+}
+
+// seedTable holds one random 64-bit constant per base (A,C,G,T),
+// mixed into the rolling hash the way ntHash mixes its per-base seed
+// table. The exact values don't matter, only that they're
+// well-distributed and fixed.
+var seedTable = [4]uint64{
+	0x3c8bfbb395c60474,
+	0x3193c18562a02b4c,
+	0x20323ed082572324,
+	0x295549f54be24456,
+}
+
+// rolling holds the incremental state needed to compute the
+// canonical (min of forward and reverse-complement) ntHash-style hash
+// of every k-mer in a base stream in amortized O(1) per base.
+type rolling struct {
+	k           int
+	codes       []int8
+	pos         int // index of the last base folded into fwd/rev, -1 if none yet
+	validSince  int // index of the most recent invalid (non-ACGT) base, -1 if none seen
+	fwd, rev    uint64
+}
+
+func newRolling(codes []int8, k int) *rolling {
+	return &rolling{k: k, codes: codes, pos: -1, validSince: -1}
+}
+
+// seedFrom computes fwd/rev from scratch for the window ending at i,
+// in O(k). Called once per contiguous run of valid bases.
+func (r *rolling) seedFrom(i int) {
+	k := r.k
+	var fwd, rev uint64
+	for p := 0; p < k; p++ {
+		base := r.codes[i-k+1+p]
+		fwd ^= bits.RotateLeft64(seedTable[base], k-1-p)
+		rev ^= bits.RotateLeft64(seedTable[3-r.codes[i-p]], k-1-p)
+	}
+	r.fwd, r.rev = fwd, rev
+	r.pos = i
+}
+
+// step advances the window by one base to end at i = r.pos+1,
+// returning false if fewer than k valid bases are available yet.
+func (r *rolling) step(i int) bool {
+	if r.codes[i] == -1 {
+		r.validSince = i
+	}
+	if i-r.k+1 <= r.validSince {
+		return false
+	}
+
+	if r.pos != i-1 {
+		r.seedFrom(i)
+		return true
+	}
+
+	k := r.k
+	out := r.codes[i-k]
+	r.fwd = bits.RotateLeft64(r.fwd, 1) ^ bits.RotateLeft64(seedTable[out], k) ^ seedTable[r.codes[i]]
+	r.rev = bits.RotateLeft64(r.rev^seedTable[3-out], -1) ^ bits.RotateLeft64(seedTable[3-r.codes[i]], k-1)
+	r.pos = i
+	return true
+}
+
+// canonical returns the canonical hash (min of forward and
+// reverse-complement) and which strand it came from.
+func (r *rolling) canonical() (uint64, Strand) {
+	if r.fwd <= r.rev {
+		return r.fwd, Plus
+	}
+	return r.rev, Minus
+}
@@ -0,0 +1,46 @@
+package sketch
+
+import "github.com/shenwei356/bio/seq"
+
+// Kmer is one canonical k-mer hash from a MinimizerIterator.
+type Kmer struct {
+	Hash   uint64
+	Pos    int    // 1-based start position on the Plus strand, consistent with seq.Seq.SubSeq
+	Strand Strand // strand the canonical hash (the smaller of fwd/revcomp) came from
+}
+
+// MinimizerIterator streams the canonical hash of every k-mer of s,
+// skipping any window that contains a non-ACGT base. It's the public
+// building block behind Minimizers and the syncmer functions, for
+// callers that want to build their own index over the raw k-mer
+// stream.
+type MinimizerIterator struct {
+	codes []int8
+	k     int
+	r     *rolling
+	i     int // next base index to fold in
+}
+
+// NewMinimizerIterator prepares to stream k-mers of s.
+func NewMinimizerIterator(s *seq.Seq, k int) *MinimizerIterator {
+	codes := make([]int8, len(s.Seq))
+	for i, b := range s.Seq {
+		codes[i] = code2bit[b]
+	}
+	return &MinimizerIterator{codes: codes, k: k, r: newRolling(codes, k), i: k - 1}
+}
+
+// Next returns the next k-mer's canonical hash, or false once the
+// sequence is exhausted.
+func (it *MinimizerIterator) Next() (Kmer, bool) {
+	for it.i < len(it.codes) {
+		i := it.i
+		it.i++
+		if !it.r.step(i) {
+			continue
+		}
+		hash, strand := it.r.canonical()
+		return Kmer{Hash: hash, Pos: i - it.k + 2, Strand: strand}, true
+	}
+	return Kmer{}, false
+}
@@ -0,0 +1,64 @@
+package sketch
+
+import "github.com/shenwei356/bio/seq"
+
+// Minimizer is one selected (w, k) minimizer or syncmer.
+type Minimizer struct {
+	Hash   uint64
+	Pos    int    // 1-based start position of the k-mer on the Plus strand
+	Strand Strand
+}
+
+// Minimizers computes the (w, k) minimizers of s: the canonical
+// k-mer hash is computed for every position, and in every window of w
+// consecutive k-mers the smallest hash (leftmost on ties) is
+// selected, using a monotonic deque so each k-mer is pushed and
+// popped at most once. Consecutive windows that select the same
+// k-mer report it only once.
+func Minimizers(s *seq.Seq, w, k int) []Minimizer {
+	it := NewMinimizerIterator(s, k)
+
+	var out []Minimizer
+	var lastPos = -1
+
+	deque := make([]Kmer, 0, w)
+	window := make([]Kmer, 0, w)
+
+	emit := func() {
+		if len(deque) == 0 {
+			return
+		}
+		min := deque[0]
+		if min.Pos != lastPos {
+			out = append(out, Minimizer{Hash: min.Hash, Pos: min.Pos, Strand: min.Strand})
+			lastPos = min.Pos
+		}
+	}
+
+	for {
+		kmer, ok := it.Next()
+		if !ok {
+			break
+		}
+
+		for len(deque) > 0 && deque[len(deque)-1].Hash > kmer.Hash {
+			deque = deque[:len(deque)-1]
+		}
+		deque = append(deque, kmer)
+
+		window = append(window, kmer)
+		if len(window) > w {
+			expired := window[0]
+			window = window[1:]
+			if len(deque) > 0 && deque[0].Pos == expired.Pos {
+				deque = deque[1:]
+			}
+		}
+
+		if len(window) == w {
+			emit()
+		}
+	}
+
+	return out
+}
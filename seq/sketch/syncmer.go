@@ -0,0 +1,74 @@
+package sketch
+
+import "github.com/shenwei356/bio/seq"
+
+// OpenSyncmers selects the open (k, s)-syncmers of s: a k-mer is kept
+// iff the smallest canonical hash among its k-s+1 constituent s-mers
+// (leftmost on ties) occurs at offset t from the start of the k-mer.
+// The conventional choice is t=0.
+func OpenSyncmers(s *seq.Seq, k, smer, t int) []Minimizer {
+	return syncmers(s, k, smer, func(minOffset, lastOffset int) bool {
+		return minOffset == t
+	})
+}
+
+// ClosedSyncmers selects the closed (k, s)-syncmers of s: a k-mer is
+// kept iff its smallest s-mer occurs at either end of the k-mer.
+func ClosedSyncmers(s *seq.Seq, k, smer int) []Minimizer {
+	return syncmers(s, k, smer, func(minOffset, lastOffset int) bool {
+		return minOffset == 0 || minOffset == lastOffset
+	})
+}
+
+// syncmers scans every valid k-mer of s and, for each, finds the
+// offset of its smallest constituent s-mer; keep reports whether that
+// offset qualifies the k-mer as a syncmer of the kind being computed.
+func syncmers(s *seq.Seq, k, smer int, keep func(minOffset, lastOffset int) bool) []Minimizer {
+	smerIt := NewMinimizerIterator(s, smer)
+
+	// Buffer s-mer hashes by their start position so each k-mer
+	// window can look back over the s-mers it contains.
+	smers := make(map[int]Kmer)
+	for {
+		km, ok := smerIt.Next()
+		if !ok {
+			break
+		}
+		smers[km.Pos] = km
+	}
+
+	kmerIt := NewMinimizerIterator(s, k)
+	lastOffset := k - smer
+
+	var out []Minimizer
+	for {
+		km, ok := kmerIt.Next()
+		if !ok {
+			break
+		}
+
+		var best Kmer
+		bestSet := false
+		for off := 0; off <= lastOffset; off++ {
+			sm, ok := smers[km.Pos+off]
+			if !ok {
+				bestSet = false
+				break
+			}
+			if !bestSet || sm.Hash < best.Hash {
+				best = sm
+				bestSet = true
+			}
+		}
+		if !bestSet {
+			continue
+		}
+
+		minOffset := best.Pos - km.Pos
+		if keep(minOffset, lastOffset) {
+			out = append(out, Minimizer{Hash: km.Hash, Pos: km.Pos, Strand: km.Strand})
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,25 @@
+package seq
+
+// Strand identifies a DNA strand, or (as a bitmask) a selection of
+// strands to scan.
+type Strand uint8
+
+// Plus and Minus are the two strands; Both selects them together.
+const (
+	Plus Strand = 1 << iota
+	Minus
+	Both = Plus | Minus
+)
+
+func (s Strand) String() string {
+	switch s {
+	case Plus:
+		return "+"
+	case Minus:
+		return "-"
+	case Both:
+		return "+/-"
+	default:
+		return ""
+	}
+}
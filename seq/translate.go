@@ -0,0 +1,47 @@
+package seq
+
+import (
+	"fmt"
+
+	"github.com/shenwei356/bio/seq/codon"
+)
+
+// Translate translates seq from the given 0-based frame (0, 1 or 2)
+// under the NCBI genetic code table, returning the protein sequence.
+// If toStop is true, translation stops at the first in-frame stop
+// codon and the stop itself is not included; otherwise stop codons
+// are emitted as '*'. cleanTerminalStop additionally strips a single
+// trailing '*' from the result, e.g. for a complete full-length ORF
+// translated with toStop=false.
+func (seq *Seq) Translate(table int, frame int, toStop bool, cleanTerminalStop bool) (*Seq, error) {
+	if frame < 0 || frame > 2 {
+		return nil, fmt.Errorf("seq: invalid frame %d: must be 0, 1 or 2", frame)
+	}
+	t, err := codon.ByID(table)
+	if err != nil {
+		return nil, err
+	}
+
+	var src []byte
+	if frame < len(seq.Seq) {
+		src = seq.Seq[frame:]
+	}
+	protein := make([]byte, 0, len(src)/3)
+
+	for i := 0; i+3 <= len(src); i += 3 {
+		aa, err := t.Translate(src[i:i+3], i == 0)
+		if err != nil {
+			return nil, err
+		}
+		if aa == '*' && toStop {
+			break
+		}
+		protein = append(protein, aa)
+	}
+
+	if cleanTerminalStop && len(protein) > 0 && protein[len(protein)-1] == '*' {
+		protein = protein[:len(protein)-1]
+	}
+
+	return NewSeqWithoutValidate(Protein, protein)
+}
@@ -0,0 +1,66 @@
+package seq
+
+import "testing"
+
+func TestTranslateShortSequence(t *testing.T) {
+	s, err := NewSeqWithoutValidate(DNA, []byte("A"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, frame := range []int{0, 1, 2} {
+		protein, err := s.Translate(1, frame, false, false)
+		if err != nil {
+			t.Fatalf("Translate(frame=%d): %s", frame, err)
+		}
+		if protein.Length() != 0 {
+			t.Errorf("Translate(frame=%d) = %q, want empty", frame, protein.Seq)
+		}
+	}
+}
+
+func TestFindORFsShortSequence(t *testing.T) {
+	s, err := NewSeqWithoutValidate(DNA, []byte("AT"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Must not panic on a sequence shorter than a full codon, on
+	// either strand.
+	orfs := s.FindORFs([]int{1}, 1, Both)
+	if len(orfs) != 0 {
+		t.Errorf("FindORFs on a 2-base sequence = %v, want none", orfs)
+	}
+}
+
+func TestFindORFsNoDuplicateAcrossTables(t *testing.T) {
+	// ATG...TAA is a valid start/stop pair under both table 1 and
+	// table 11 (table 11 only diverges from the standard code in its
+	// extra start codons), so it must be reported once, under the
+	// first matching table, not once per table.
+	s, err := NewSeqWithoutValidate(DNA, []byte("ATGAAATAA"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	orfs := s.FindORFs([]int{1, 11}, 1, Plus)
+	if len(orfs) != 1 {
+		t.Fatalf("FindORFs([1, 11]) = %d ORFs, want 1: %v", len(orfs), orfs)
+	}
+}
+
+func TestTranslateToStop(t *testing.T) {
+	// ATG AAA TAA TTT -> M K * (stops before the trailing codon)
+	s, err := NewSeqWithoutValidate(DNA, []byte("ATGAAATAATTT"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	protein, err := s.Translate(1, 0, true, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(protein.Seq) != "MK" {
+		t.Errorf("Translate(toStop=true) = %q, want %q", protein.Seq, "MK")
+	}
+}
@@ -0,0 +1,169 @@
+package fasta
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+)
+
+// bgzfSeeker provides io.ReaderAt-style random access into a
+// BGZF-compressed file (a concatenation of independent gzip blocks,
+// as written by `bgzip`) using a parsed .gzi index to find the block
+// that contains a given uncompressed offset. Because every BGZF block
+// is itself a complete gzip stream, and compress/gzip.Reader
+// transparently decodes concatenated streams back to back, decoding
+// from the start of a block reproduces the original uncompressed
+// stream from that point on.
+type bgzfSeeker struct {
+	fh  *os.File
+	gzi *GzIndex
+}
+
+func newBgzfSeeker(file string, gzi *GzIndex) (*bgzfSeeker, error) {
+	fh, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	return &bgzfSeeker{fh: fh, gzi: gzi}, nil
+}
+
+// ReadAt decompresses len(buf) bytes starting at uncompressed offset
+// off, satisfying the io.ReaderAt contract.
+func (b *bgzfSeeker) ReadAt(buf []byte, off int64) (int, error) {
+	block := b.gzi.blockFor(off)
+	if _, err := b.fh.Seek(int64(block.CompressedOffset), io.SeekStart); err != nil {
+		return 0, err
+	}
+
+	gz, err := gzip.NewReader(b.fh)
+	if err != nil {
+		return 0, err
+	}
+	defer gz.Close()
+
+	if skip := off - int64(block.UncompressedOffset); skip > 0 {
+		if _, err := io.CopyN(io.Discard, gz, skip); err != nil {
+			return 0, err
+		}
+	}
+
+	return io.ReadFull(gz, buf)
+}
+
+func (b *bgzfSeeker) Close() error {
+	return b.fh.Close()
+}
+
+// bgzfBlockSize is the uncompressed size at which bgzfWriter starts a
+// new block, well under BGZF's 64KiB-compressed-block ceiling for
+// typical FASTA line content.
+const bgzfBlockSize = 1 << 16
+
+// bgzfExtra is the gzip FEXTRA subfield BGZF blocks carry: subfield ID
+// "BC", a 2-byte little-endian subfield length of 2, and a 2-byte
+// little-endian BSIZE placeholder (total block size minus one) that
+// bgzfWriter patches in once the block's compressed length is known.
+var bgzfExtra = []byte{'B', 'C', 2, 0, 0, 0}
+
+// bsizeOffset is where BSIZE lands inside a block written with
+// bgzfExtra as its gzip Extra field: the 10-byte fixed gzip header,
+// plus 2 bytes of XLEN, plus the 4 leading bytes of bgzfExtra
+// (subfield ID and subfield length) that precede BSIZE itself.
+const bsizeOffset = 10 + 2 + 4
+
+// bgzfEOF is the canonical empty BGZF block every compliant writer
+// appends as an end-of-file marker, letting readers detect truncation.
+var bgzfEOF = []byte{
+	0x1f, 0x8b, 0x08, 0x04, 0x00, 0x00, 0x00, 0x00, 0x00, 0xff, 0x06, 0x00,
+	0x42, 0x43, 0x02, 0x00, 0x1b, 0x00, 0x03, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00,
+}
+
+// bgzfWriter writes a real BGZF stream: a concatenation of
+// independent gzip members, each carrying the "BC" extra subfield
+// that records its own compressed size, terminated by the canonical
+// empty EOF block. Close also writes a .gzi index alongside gziFile,
+// recording the offset of every block boundary after the first, so
+// the result can be seeked into block-by-block with bgzfSeeker
+// instead of decompressed from the start — the same layout `bgzip -r`
+// produces.
+type bgzfWriter struct {
+	fh      *os.File
+	gziFile string
+
+	buf      bytes.Buffer
+	gz       *gzip.Writer
+	buffered int // uncompressed bytes written to gz since the last flush
+
+	compressedOffset   uint64
+	uncompressedOffset uint64
+	entries            []gziEntry
+}
+
+func newBgzfWriter(fh *os.File, gziFile string) *bgzfWriter {
+	b := &bgzfWriter{fh: fh, gziFile: gziFile}
+	b.gz = b.newMember()
+	return b
+}
+
+func (b *bgzfWriter) newMember() *gzip.Writer {
+	b.buf.Reset()
+	gz, _ := gzip.NewWriterLevel(&b.buf, gzip.DefaultCompression)
+	gz.Header.Extra = append([]byte(nil), bgzfExtra...)
+	return gz
+}
+
+func (b *bgzfWriter) Write(p []byte) (int, error) {
+	n, err := b.gz.Write(p)
+	b.buffered += n
+	if err == nil && b.buffered >= bgzfBlockSize {
+		err = b.flushBlock()
+	}
+	return n, err
+}
+
+// flushBlock closes out the current gzip member, patches its BSIZE
+// field now that the member's total length is known, writes it out,
+// and records its start as a block boundary before opening the next
+// member.
+func (b *bgzfWriter) flushBlock() error {
+	if err := b.gz.Close(); err != nil {
+		return err
+	}
+
+	data := b.buf.Bytes()
+	binary.LittleEndian.PutUint16(data[bsizeOffset:], uint16(len(data)-1))
+	if _, err := b.fh.Write(data); err != nil {
+		return err
+	}
+
+	b.compressedOffset += uint64(len(data))
+	b.uncompressedOffset += uint64(b.buffered)
+	b.entries = append(b.entries, gziEntry{CompressedOffset: b.compressedOffset, UncompressedOffset: b.uncompressedOffset})
+
+	b.buffered = 0
+	b.gz = b.newMember()
+	return nil
+}
+
+func (b *bgzfWriter) Close() error {
+	if b.buffered > 0 {
+		if err := b.flushBlock(); err != nil {
+			return err
+		}
+	} else {
+		b.gz.Close() // discard: an empty member, superseded by bgzfEOF below
+	}
+
+	if _, err := b.fh.Write(bgzfEOF); err != nil {
+		return err
+	}
+	if err := WriteGzIndex(b.gziFile, b.entries); err != nil {
+		return fmt.Errorf("bgzf: writing %s: %s", b.gziFile, err)
+	}
+
+	return b.fh.Close()
+}
@@ -0,0 +1,206 @@
+package fasta
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// FaiRecord is one entry of a samtools-style .fai index: the sequence
+// name, its length in bases, the byte offset of its first base, and
+// the bases-per-line / bytes-per-line needed to compute byte offsets
+// for an arbitrary 1-based position without scanning the file.
+type FaiRecord struct {
+	Name      string
+	Length    int64
+	Offset    int64
+	LineBases int64
+	LineWidth int64
+}
+
+// Index is an in-memory, ordered representation of a .fai file with a
+// name-to-record lookup for random access.
+type Index struct {
+	Records []FaiRecord
+	byName  map[string]int
+}
+
+// Names returns sequence names in the order they appear in the index.
+func (idx *Index) Names() []string {
+	names := make([]string, len(idx.Records))
+	for i, rec := range idx.Records {
+		names[i] = rec.Name
+	}
+	return names
+}
+
+func (idx *Index) recordByName(name string) (FaiRecord, bool) {
+	i, ok := idx.byName[name]
+	if !ok {
+		return FaiRecord{}, false
+	}
+	return idx.Records[i], true
+}
+
+func newIndex() *Index {
+	return &Index{byName: make(map[string]int)}
+}
+
+func (idx *Index) add(rec FaiRecord) {
+	idx.byName[rec.Name] = len(idx.Records)
+	idx.Records = append(idx.Records, rec)
+}
+
+// BuildFaiIndex scans a FASTA file and builds a samtools-compatible
+// .fai index, following the same rules as `samtools faidx`: every
+// sequence must use a single consistent line width, except for its
+// last line which may be shorter. A file whose name ends in ".gz"
+// (gzip or BGZF) is decompressed while scanning, and its offsets are
+// offsets into the decompressed content.
+func BuildFaiIndex(file string) (*Index, error) {
+	fh, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer fh.Close()
+
+	var src io.Reader = fh
+	if strings.HasSuffix(file, ".gz") {
+		gz, err := gzip.NewReader(fh)
+		if err != nil {
+			return nil, err
+		}
+		defer gz.Close()
+		src = gz
+	}
+
+	idx := newIndex()
+	reader := bufio.NewReader(src)
+
+	var offset int64
+	var rec *FaiRecord
+	var shortLineSeen bool
+
+	flush := func() error {
+		if rec == nil {
+			return nil
+		}
+		if rec.Name == "" {
+			return fmt.Errorf("fasta index: empty sequence name in %s", file)
+		}
+		idx.add(*rec)
+		return nil
+	}
+
+	for {
+		line, err := reader.ReadBytes('\n')
+		n := int64(len(line))
+
+		if len(line) > 0 && line[0] == '>' {
+			if ferr := flush(); ferr != nil {
+				return nil, ferr
+			}
+			name := string(bytes.TrimRight(line[1:], "\r\n"))
+			if i := strings.IndexAny(name, " \t"); i >= 0 {
+				name = name[:i]
+			}
+			rec = &FaiRecord{Name: name, Offset: offset + n}
+			shortLineSeen = false
+		} else if rec != nil && n > 0 {
+			bases := int64(len(bytes.TrimRight(line, "\r\n")))
+			if shortLineSeen && bases > 0 {
+				return nil, fmt.Errorf("fasta index: %s: inconsistent line length in sequence %q", file, rec.Name)
+			}
+			if rec.LineBases == 0 {
+				rec.LineBases, rec.LineWidth = bases, n
+			} else if bases != rec.LineBases {
+				if bases > rec.LineBases {
+					return nil, fmt.Errorf("fasta index: %s: inconsistent line length in sequence %q", file, rec.Name)
+				}
+				shortLineSeen = true
+			}
+			rec.Length += bases
+		}
+
+		offset += n
+		if err != nil {
+			break
+		}
+	}
+	if ferr := flush(); ferr != nil {
+		return nil, ferr
+	}
+
+	return idx, nil
+}
+
+// ReadFaiIndex parses an existing .fai file (five tab-separated
+// columns: name, length, offset, linebases, linewidth).
+func ReadFaiIndex(faiFile string) (*Index, error) {
+	fh, err := os.Open(faiFile)
+	if err != nil {
+		return nil, err
+	}
+	defer fh.Close()
+
+	idx := newIndex()
+	scanner := bufio.NewScanner(fh)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		if len(fields) < 5 {
+			return nil, fmt.Errorf("fasta index: %s: malformed line: %q", faiFile, line)
+		}
+		length, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("fasta index: %s: bad length: %s", faiFile, err)
+		}
+		offset, err := strconv.ParseInt(fields[2], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("fasta index: %s: bad offset: %s", faiFile, err)
+		}
+		lineBases, err := strconv.ParseInt(fields[3], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("fasta index: %s: bad linebases: %s", faiFile, err)
+		}
+		lineWidth, err := strconv.ParseInt(fields[4], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("fasta index: %s: bad linewidth: %s", faiFile, err)
+		}
+		idx.add(FaiRecord{
+			Name:      fields[0],
+			Length:    length,
+			Offset:    offset,
+			LineBases: lineBases,
+			LineWidth: lineWidth,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return idx, nil
+}
+
+// WriteFaiIndex writes idx to faiFile in samtools .fai format.
+func WriteFaiIndex(idx *Index, faiFile string) error {
+	fh, err := os.Create(faiFile)
+	if err != nil {
+		return err
+	}
+	defer fh.Close()
+
+	w := bufio.NewWriter(fh)
+	for _, rec := range idx.Records {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\n", rec.Name, rec.Length, rec.Offset, rec.LineBases, rec.LineWidth)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,96 @@
+package fasta
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"sort"
+)
+
+// gziEntry records one BGZF block boundary: the byte offset of the
+// block in the compressed file and the uncompressed offset of the
+// first byte the block decompresses to.
+type gziEntry struct {
+	CompressedOffset   uint64
+	UncompressedOffset uint64
+}
+
+// GzIndex is a parsed .gzi index (as produced by `bgzip -r`), used to
+// translate an uncompressed file offset into the compressed block
+// that contains it, so a BGZF file can be seeked without
+// decompressing everything before the target region.
+type GzIndex struct {
+	entries []gziEntry // sorted by UncompressedOffset; implicit (0, 0) entry is not stored
+}
+
+// ReadGzIndex parses a .gzi file. Its format is a little-endian
+// uint64 entry count followed by that many (compressed, uncompressed)
+// uint64 offset pairs.
+func ReadGzIndex(gziFile string) (*GzIndex, error) {
+	fh, err := os.Open(gziFile)
+	if err != nil {
+		return nil, err
+	}
+	defer fh.Close()
+
+	r := bufio.NewReader(fh)
+	var count uint64
+	if err := binary.Read(r, binary.LittleEndian, &count); err != nil {
+		return nil, fmt.Errorf("gzi index: %s: %s", gziFile, err)
+	}
+
+	entries := make([]gziEntry, count)
+	for i := uint64(0); i < count; i++ {
+		var compressed, uncompressed uint64
+		if err := binary.Read(r, binary.LittleEndian, &compressed); err != nil {
+			return nil, fmt.Errorf("gzi index: %s: %s", gziFile, err)
+		}
+		if err := binary.Read(r, binary.LittleEndian, &uncompressed); err != nil {
+			return nil, fmt.Errorf("gzi index: %s: %s", gziFile, err)
+		}
+		entries[i] = gziEntry{CompressedOffset: compressed, UncompressedOffset: uncompressed}
+	}
+
+	return &GzIndex{entries: entries}, nil
+}
+
+// WriteGzIndex writes a .gzi index for entries (sorted by
+// UncompressedOffset, each marking the start of a BGZF block other
+// than the implicit first one at (0, 0)) in the same format
+// ReadGzIndex parses.
+func WriteGzIndex(gziFile string, entries []gziEntry) error {
+	fh, err := os.Create(gziFile)
+	if err != nil {
+		return err
+	}
+	defer fh.Close()
+
+	w := bufio.NewWriter(fh)
+	if err := binary.Write(w, binary.LittleEndian, uint64(len(entries))); err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if err := binary.Write(w, binary.LittleEndian, e.CompressedOffset); err != nil {
+			return err
+		}
+		if err := binary.Write(w, binary.LittleEndian, e.UncompressedOffset); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+// blockFor returns the block boundary whose uncompressed range
+// contains uoffset, i.e. the last entry with UncompressedOffset <=
+// uoffset (or the implicit first block starting at (0, 0)).
+func (g *GzIndex) blockFor(uoffset int64) gziEntry {
+	u := uint64(uoffset)
+	i := sort.Search(len(g.entries), func(i int) bool {
+		return g.entries[i].UncompressedOffset > u
+	})
+	if i == 0 {
+		return gziEntry{}
+	}
+	return g.entries[i-1]
+}
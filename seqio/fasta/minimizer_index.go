@@ -0,0 +1,62 @@
+package fasta
+
+import (
+	"github.com/shenwei356/bio/seq"
+	"github.com/shenwei356/bio/seq/sketch"
+)
+
+// Hit is one seed match returned by MinimizerIndex.Query: the minimizer
+// it came from matches a minimizer recorded for RecordID at Pos on
+// Strand when the index was built.
+type Hit struct {
+	RecordID string
+	Pos      int
+	Strand   sketch.Strand
+}
+
+// MinimizerIndex maps a canonical (W, K) minimizer hash to every
+// record position it was selected at, giving seed matches for
+// downstream chaining (the basis of tools like minimap2).
+type MinimizerIndex struct {
+	W, K  int
+	index map[uint64][]Hit
+}
+
+// NewMinimizerIndex creates an empty index for (w, k) minimizers.
+func NewMinimizerIndex(w, k int) *MinimizerIndex {
+	return &MinimizerIndex{W: w, K: k, index: make(map[uint64][]Hit)}
+}
+
+// BuildMinimizerIndex drains reader.Ch, adding the (w, k) minimizers
+// of every record to a new MinimizerIndex.
+func BuildMinimizerIndex(reader *FastaReader, w, k int) (*MinimizerIndex, error) {
+	idx := NewMinimizerIndex(w, k)
+	for chunk := range reader.Ch {
+		if chunk.Err != nil {
+			return nil, chunk.Err
+		}
+		for _, rec := range chunk.Data {
+			idx.Add(string(rec.Name), rec.Seq)
+		}
+	}
+	return idx, nil
+}
+
+// Add computes the index's (W, K) minimizers of s and records them
+// under recordID.
+func (idx *MinimizerIndex) Add(recordID string, s *seq.Seq) {
+	for _, m := range sketch.Minimizers(s, idx.W, idx.K) {
+		idx.index[m.Hash] = append(idx.index[m.Hash], Hit{RecordID: recordID, Pos: m.Pos, Strand: m.Strand})
+	}
+}
+
+// Query computes the index's (W, K) minimizers of s and returns every
+// recorded Hit sharing a minimizer hash with it, as candidate seeds
+// for downstream chaining.
+func (idx *MinimizerIndex) Query(s *seq.Seq) []Hit {
+	var hits []Hit
+	for _, m := range sketch.Minimizers(s, idx.W, idx.K) {
+		hits = append(hits, idx.index[m.Hash]...)
+	}
+	return hits
+}
@@ -0,0 +1,259 @@
+package fasta
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/shenwei356/util/byteutil"
+)
+
+// ErrorPolicy controls how Pipeline.Run reacts to a transform error.
+type ErrorPolicy int
+
+const (
+	// FailFast aborts the whole pipeline on the first transform error.
+	FailFast ErrorPolicy = iota
+	// SkipRecord drops the offending record and continues.
+	SkipRecord
+)
+
+// PipelineOptions configures a Pipeline's output.
+type PipelineOptions struct {
+	LineWidth int         // 0 = no wrap, matching seqkit's recent move to stop wrapping output
+	Gzip      bool        // gzip-compress the output
+	BGZF      bool        // write BGZF (with a sibling .gzi index) instead of a single gzip stream; takes precedence over Gzip
+	OnError   ErrorPolicy // what to do when transform returns an error
+}
+
+// Pipeline pairs a FastaReader's chunked output with a pool of worker
+// goroutines applying a caller-supplied transform, and a single
+// writer goroutine that reassembles transformed chunks by
+// FastaRecordChunk.ID before writing, so output order matches input
+// order regardless of which worker finishes a chunk first. This is
+// what makes FastaReader's chunked, channel-based design pay off for
+// CPU-bound per-record work like translation, trimming, or k-mer
+// counting.
+type Pipeline struct {
+	reader  *FastaReader
+	writer  io.WriteCloser
+	workers int
+	opts    PipelineOptions
+}
+
+// NewPipeline opens outFile for writing (truncating it if it exists)
+// and returns a Pipeline reading from reader with the given number of
+// worker goroutines.
+func NewPipeline(reader *FastaReader, outFile string, workers int, opts PipelineOptions) (*Pipeline, error) {
+	if workers < 1 {
+		workers = 1
+	}
+
+	fh, err := os.Create(outFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var w io.WriteCloser
+	switch {
+	case opts.BGZF:
+		w = newBgzfWriter(fh, outFile+".gzi")
+	case opts.Gzip:
+		w = gzipWriteCloser{fh: fh, gz: gzip.NewWriter(fh)}
+	default:
+		w = fh
+	}
+
+	return &Pipeline{reader: reader, writer: w, workers: workers, opts: opts}, nil
+}
+
+type gzipWriteCloser struct {
+	fh *os.File
+	gz *gzip.Writer
+}
+
+func (w gzipWriteCloser) Write(p []byte) (int, error) { return w.gz.Write(p) }
+
+func (w gzipWriteCloser) Close() error {
+	if err := w.gz.Close(); err != nil {
+		return err
+	}
+	return w.fh.Close()
+}
+
+type pipelineResult struct {
+	id   uint64
+	data []*FastaRecord
+	err  error
+}
+
+// Run reads records from the Pipeline's reader, applies transform to
+// each one across Pipeline's worker pool, and writes the results in
+// original order. Cancelling ctx (e.g. on Ctrl-C) stops the reader
+// and every worker and causes Run to return ctx.Err(); the partially
+// written output is left in place.
+func (p *Pipeline) Run(ctx context.Context, transform func(*FastaRecord) (*FastaRecord, error)) error {
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	go func() {
+		<-runCtx.Done()
+		p.reader.Cancel()
+	}()
+
+	jobs := make(chan FastaRecordChunk)
+	results := make(chan pipelineResult)
+
+	var wg sync.WaitGroup
+	for n := 0; n < p.workers; n++ {
+		wg.Add(1)
+		go p.work(runCtx, cancel, &wg, jobs, results, transform)
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	go p.feed(runCtx, cancel, jobs, results)
+
+	err := p.reassemble(results)
+	if ctx.Err() != nil {
+		err = ctx.Err()
+	}
+	return err
+}
+
+// work applies transform to every record of every chunk received on
+// jobs, until jobs is closed or ctx is cancelled. A non-SkipRecord
+// transform error calls cancel so FailFast aborts the pipeline
+// immediately instead of draining the rest of the input first.
+func (p *Pipeline) work(ctx context.Context, cancel context.CancelFunc, wg *sync.WaitGroup, jobs <-chan FastaRecordChunk, results chan<- pipelineResult, transform func(*FastaRecord) (*FastaRecord, error)) {
+	defer wg.Done()
+
+	for chunk := range jobs {
+		out := make([]*FastaRecord, 0, len(chunk.Data))
+		for _, rec := range chunk.Data {
+			tr, err := transform(rec)
+			if err != nil {
+				if p.opts.OnError == SkipRecord {
+					continue
+				}
+				select {
+				case results <- pipelineResult{id: chunk.ID, err: err}:
+				case <-ctx.Done():
+				}
+				cancel()
+				return
+			}
+			out = append(out, tr)
+		}
+
+		select {
+		case results <- pipelineResult{id: chunk.ID, data: out}:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// feed forwards chunks from the reader to jobs, stopping and
+// cancelling the pipeline if the reader reports an error.
+func (p *Pipeline) feed(ctx context.Context, cancel context.CancelFunc, jobs chan<- FastaRecordChunk, results chan<- pipelineResult) {
+	defer close(jobs)
+
+	for {
+		select {
+		case chunk, ok := <-p.reader.Ch:
+			if !ok {
+				return
+			}
+			if chunk.Err != nil {
+				select {
+				case results <- pipelineResult{id: chunk.ID, err: chunk.Err}:
+				case <-ctx.Done():
+				}
+				cancel()
+				p.drain()
+				return
+			}
+			select {
+			case jobs <- chunk:
+			case <-ctx.Done():
+				p.drain()
+				return
+			}
+		case <-ctx.Done():
+			p.drain()
+			return
+		}
+	}
+}
+
+// drain receives and discards every remaining chunk from the reader
+// until it closes its channel. Once ctx is cancelled, feed stops
+// forwarding chunks to jobs, but FastaReader's own goroutine can
+// already be parked on an unbuffered send to that channel with no one
+// left to receive it: it only notices cancellation the next time it
+// reaches its own top-of-loop check, which it can never reach while
+// blocked on that send. Draining unblocks it so it can observe the
+// cancellation, close its channel, and exit instead of leaking its
+// goroutine and open file handle.
+func (p *Pipeline) drain() {
+	for range p.reader.Ch {
+	}
+}
+
+// reassemble buffers out-of-order results until the next expected
+// chunk ID is available, then writes it, repeating until results is
+// drained. It returns the first transform/read error encountered, if
+// any.
+func (p *Pipeline) reassemble(results <-chan pipelineResult) error {
+	pending := make(map[uint64][]*FastaRecord)
+	var next uint64
+	var firstErr error
+
+	for res := range results {
+		if res.err != nil {
+			if firstErr == nil {
+				firstErr = res.err
+			}
+			continue
+		}
+
+		pending[res.id] = res.data
+		for {
+			data, ok := pending[next]
+			if !ok {
+				break
+			}
+			delete(pending, next)
+			next++
+
+			if firstErr != nil {
+				continue
+			}
+			for _, rec := range data {
+				if err := p.writeRecord(rec); err != nil {
+					firstErr = err
+				}
+			}
+		}
+	}
+
+	if err := p.writer.Close(); err != nil && firstErr == nil {
+		firstErr = err
+	}
+	return firstErr
+}
+
+func (p *Pipeline) writeRecord(rec *FastaRecord) error {
+	body := rec.Seq.Seq
+	if p.opts.LineWidth > 0 {
+		body = byteutil.WrapByteSlice(rec.Seq.Seq, p.opts.LineWidth)
+	}
+	_, err := fmt.Fprintf(p.writer, ">%s\n%s\n", rec.Name, body)
+	return err
+}
@@ -0,0 +1,136 @@
+package fasta
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/shenwei356/bio/seq"
+)
+
+var errWantTransform = errors.New("transform error")
+
+func writeFasta(t *testing.T, n int) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	for i := 0; i < n; i++ {
+		buf.WriteString(">seq" + strconv.Itoa(i) + "\nACGT\n")
+	}
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "in.fa")
+	if err := os.WriteFile(file, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func TestPipelinePreservesOrder(t *testing.T) {
+	in := writeFasta(t, 20)
+	reader, err := NewFastaReader(seq.DNA, in, 0, 1, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := filepath.Join(t.TempDir(), "out.fa")
+	p, err := NewPipeline(reader, out, 4, PipelineOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = p.Run(context.Background(), func(rec *FastaRecord) (*FastaRecord, error) {
+		return rec, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, name := range bytes.Split(bytes.TrimSpace(data), []byte("\n")) {
+		if i%2 != 0 {
+			continue
+		}
+		want := ">seq" + strconv.Itoa(i/2)
+		if string(name) != want {
+			t.Fatalf("record %d = %q, want %q (output not in input order)", i/2, name, want)
+		}
+	}
+}
+
+func TestPipelineFailFastStopsEarly(t *testing.T) {
+	in := writeFasta(t, 500)
+	reader, err := NewFastaReader(seq.DNA, in, 0, 1, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := filepath.Join(t.TempDir(), "out.fa")
+	p, err := NewPipeline(reader, out, 1, PipelineOptions{OnError: FailFast})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var calls int64
+	err = p.Run(context.Background(), func(rec *FastaRecord) (*FastaRecord, error) {
+		n := atomic.AddInt64(&calls, 1)
+		if n == 1 {
+			return nil, errWantTransform
+		}
+		return rec, nil
+	})
+	if err != errWantTransform {
+		t.Fatalf("Run() error = %v, want %v", err, errWantTransform)
+	}
+	if calls >= 500 {
+		t.Errorf("transform was called %d times out of 500 records; FailFast should have aborted well before the end", calls)
+	}
+}
+
+func TestPipelineFailFastDrainsReader(t *testing.T) {
+	// Many chunks with no buffering, so the reader goroutine is almost
+	// certainly still mid-stream (and likely blocked sending its next
+	// chunk) when the first transform error cancels the pipeline.
+	in := writeFasta(t, 5000)
+
+	// Counted before NewFastaReader starts the reader goroutine, so a
+	// reader goroutine still alive at the end shows up as an excess.
+	before := runtime.NumGoroutine()
+	reader, err := NewFastaReader(seq.DNA, in, 0, 1, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := filepath.Join(t.TempDir(), "out.fa")
+	p, err := NewPipeline(reader, out, 1, PipelineOptions{OnError: FailFast})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := p.Run(context.Background(), func(rec *FastaRecord) (*FastaRecord, error) {
+		return nil, errWantTransform
+	}); err != errWantTransform {
+		t.Fatalf("Run() error = %v, want %v", err, errWantTransform)
+	}
+
+	// feed's drain of the reader's channel runs in the background
+	// after Run returns; give it a moment to unblock the reader
+	// goroutine and let it exit.
+	deadline := time.Now().Add(2 * time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got := runtime.NumGoroutine(); got > before {
+		t.Errorf("goroutine count = %d, want <= %d; the reader goroutine appears to have leaked after a FailFast cancellation", got, before)
+	}
+}
@@ -0,0 +1,289 @@
+package fastq
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/brentp/xopen"
+	"github.com/shenwei356/bio/seq"
+)
+
+// FastqRecord struct
+type FastqRecord struct {
+	ID   []byte   // id
+	Name []byte   // full name
+	Seq  *seq.Seq // seq, with Qual populated
+}
+
+func (fastqRecord FastqRecord) String() string {
+	return fmt.Sprintf("@%s\n%s\n+\n%s", fastqRecord.Name,
+		fastqRecord.Seq.Seq, fastqRecord.Seq.Qual)
+}
+
+// NewFastqRecord is constructor of type FastqRecord
+func NewFastqRecord(t *seq.Alphabet, id, name, s, q []byte) (*FastqRecord, error) {
+	sq, err := seq.NewSeqWithQual(t, s, q)
+	if err != nil {
+		return nil, fmt.Errorf("error when parsing seq: %s (%s)", id, err)
+	}
+	return &FastqRecord{id, name, sq}, nil
+}
+
+// FastqRecordChunk is
+type FastqRecordChunk struct {
+	ID   uint64
+	Data []*FastqRecord
+	Err  error
+}
+
+// FastqReader asynchronously parses a FASTQ file with buffer, where
+// each buffer contains a chunk of multiple fastq records
+// (FastqRecordChunk). FastqReader also supports safe cancellation,
+// mirroring fasta.FastaReader.
+type FastqReader struct {
+	t  *seq.Alphabet // alphabet
+	fh *xopen.Reader // file handle, xopen is such a wonderful package
+
+	BufferSize int                   // buffer size
+	ChunkSize  int                   // chunk size
+	Ch         chan FastqRecordChunk // chanel for output of records chunks
+	IDRegexp   *regexp.Regexp        // regexp for parsing record id
+
+	firstseq  bool          // for guess alphabet by the first seq
+	done      chan struct{} // for cancellation
+	finished  bool          // for cancellation
+	cancelled bool          // for cancellation
+}
+
+// regexp for checking idRegexp string.
+// The regular expression must contains "(" and ")" to capture matched ID
+var reCheckIDregexpStr = regexp.MustCompile(`\(.+\)`)
+
+// DefaultIDRegexp is the default ID parsing regular expression
+var DefaultIDRegexp = `^([^\s]+)\s?`
+
+// NewFastqReader is constructor of FastqReader.
+//
+// Parameters:
+//
+//        t            sequence alphabet
+//                     if nil is given, it will guess alphabet by the first record
+//        file         file name, "-" for stdin
+//        bufferSize   buffer size
+//        chunkSize    chunk size
+//        idRegexp     id parsing regular expression string, must contains "(" and ")" to capture matched ID
+//                     "" for default value: `^([^\s]+)\s?`
+//                     if record head does not match the idRegxp, whole name will be the id
+//
+func NewFastqReader(t *seq.Alphabet, file string, bufferSize int, chunkSize int, idRegexp string) (*FastqReader, error) {
+	if bufferSize < 0 {
+		bufferSize = 0
+	}
+	if chunkSize < 1 {
+		chunkSize = 1
+	}
+
+	var r *regexp.Regexp
+	if idRegexp == "" {
+		r = regexp.MustCompile(DefaultIDRegexp)
+	} else {
+		if !reCheckIDregexpStr.MatchString(idRegexp) {
+			return nil, fmt.Errorf(`regular expression must contains "(" and ")" to capture matched ID. default: %s`, DefaultIDRegexp)
+		}
+		var err error
+		r, err = regexp.Compile(idRegexp)
+		if err != nil {
+			return nil, fmt.Errorf("fail to compile regexp: %s", idRegexp)
+		}
+	}
+
+	fh, err := xopen.Ropen(file)
+	if err != nil {
+		return nil, err
+	}
+
+	fastqReader := &FastqReader{
+		t:          t,
+		fh:         fh,
+		BufferSize: bufferSize,
+		ChunkSize:  chunkSize,
+		Ch:         make(chan FastqRecordChunk, bufferSize),
+		IDRegexp:   r,
+		firstseq:   true,
+		done:       make(chan struct{}),
+		finished:   false,
+		cancelled:  false,
+	}
+
+	fastqReader.read()
+
+	return fastqReader, nil
+}
+
+var reTrimRightSpace = regexp.MustCompile(`[\r\n]+$`)
+
+// ErrorCanceled means that the reading process is canceled
+var ErrorCanceled = errors.New("reading canceled")
+
+// ErrorMalformedFastq means a record did not follow the 4-line
+// @name / seq / +[name] / qual structure, or seq and qual lengths
+// did not match once wrapped lines were joined.
+var ErrorMalformedFastq = errors.New("malformed fastq record")
+
+func (fastqReader *FastqReader) read() {
+	go func() {
+		reader := bufio.NewReader(fastqReader.fh)
+		var id uint64
+		var i int
+		chunkData := make([]*FastqRecord, fastqReader.ChunkSize)
+
+		emit := func(rec *FastqRecord, err error) bool {
+			if rec != nil {
+				chunkData[i] = rec
+				i++
+			}
+			if err != nil || i == fastqReader.ChunkSize {
+				fastqReader.Ch <- FastqRecordChunk{id, chunkData[0:i], err}
+				id++
+				i = 0
+				chunkData = make([]*FastqRecord, fastqReader.ChunkSize)
+			}
+			return err != nil
+		}
+
+		for {
+			select {
+			case <-fastqReader.done:
+				if !fastqReader.finished {
+					fastqReader.finished = true
+					fastqReader.fh.Close()
+					emit(nil, ErrorCanceled)
+					close(fastqReader.Ch)
+					return
+				}
+			default:
+			}
+
+			name, ok := fastqReader.readLine(reader)
+			if !ok {
+				fastqReader.finished = true
+				fastqReader.fh.Close()
+				close(fastqReader.Ch)
+				return
+			}
+			if len(name) == 0 || name[0] != '@' {
+				fastqReader.finished = true
+				fastqReader.fh.Close()
+				emit(nil, fmt.Errorf("%s: expected '@' at start of header", ErrorMalformedFastq))
+				close(fastqReader.Ch)
+				return
+			}
+			name = name[1:]
+
+			sequence, plusOK := fastqReader.readSeqLines(reader)
+			if !plusOK {
+				fastqReader.finished = true
+				fastqReader.fh.Close()
+				emit(nil, fmt.Errorf("%s: missing '+' separator for %s", ErrorMalformedFastq, name))
+				close(fastqReader.Ch)
+				return
+			}
+
+			quality, qualOK := fastqReader.readQualLines(reader, len(sequence))
+			if !qualOK || len(sequence) != len(quality) {
+				fastqReader.finished = true
+				fastqReader.fh.Close()
+				emit(nil, fmt.Errorf("%s: unmatched lengths of seq (%d) and qual (%d) for %s",
+					ErrorMalformedFastq, len(sequence), len(quality), name))
+				close(fastqReader.Ch)
+				return
+			}
+
+			if fastqReader.firstseq {
+				if fastqReader.t == nil {
+					fastqReader.t = seq.GuessAlphabetLessConservatively(sequence)
+				}
+				fastqReader.firstseq = false
+			}
+
+			rec, err := NewFastqRecord(fastqReader.t, fastqReader.parseHeadID(name), name, sequence, quality)
+			if err != nil {
+				fastqReader.finished = true
+				fastqReader.fh.Close()
+				emit(nil, err)
+				close(fastqReader.Ch)
+				return
+			}
+			emit(rec, nil)
+		}
+	}()
+}
+
+// readLine returns the next line with its terminator trimmed, and
+// false once EOF is reached with nothing left to read.
+func (fastqReader *FastqReader) readLine(reader *bufio.Reader) ([]byte, bool) {
+	line, err := reader.ReadBytes('\n')
+	if len(line) == 0 && err != nil {
+		return nil, false
+	}
+	line = reTrimRightSpace.ReplaceAll(line, []byte(""))
+	return line, true
+}
+
+// readSeqLines accumulates a (possibly wrapped) sequence across
+// multiple lines until it finds the "+" separator line, the same way
+// it accumulates wrapped FASTA sequence lines. The first line that
+// starts with '+' is taken as the separator and ends the sequence.
+// It returns false if EOF is reached before a separator line appears.
+func (fastqReader *FastqReader) readSeqLines(reader *bufio.Reader) ([]byte, bool) {
+	var sequence []byte
+	for {
+		line, ok := fastqReader.readLine(reader)
+		if !ok {
+			return sequence, false
+		}
+		if len(line) > 0 && line[0] == '+' {
+			return sequence, true
+		}
+		sequence = append(sequence, line...)
+	}
+}
+
+// readQualLines accumulates quality lines until their combined length
+// reaches seqLen, so a wrapped quality line is never mistaken for the
+// next record's header even when it starts with '@' or '+'. It
+// returns false if EOF is reached first.
+func (fastqReader *FastqReader) readQualLines(reader *bufio.Reader, seqLen int) ([]byte, bool) {
+	var quality []byte
+	for len(quality) < seqLen {
+		line, ok := fastqReader.readLine(reader)
+		if !ok {
+			return quality, false
+		}
+		quality = append(quality, line...)
+	}
+	return quality, true
+}
+
+func (fastqReader *FastqReader) parseHeadID(head []byte) []byte {
+	found := fastqReader.IDRegexp.FindAllSubmatch(head, -1)
+	if found == nil { // not match
+		return head
+	}
+	return found[0][1]
+}
+
+// Cancel method cancel the reading process
+func (fastqReader *FastqReader) Cancel() {
+	if !fastqReader.finished && !fastqReader.cancelled {
+		close(fastqReader.done)
+		fastqReader.cancelled = true
+	}
+}
+
+// Alphabet returns Alphabet of the file
+func (fastqReader *FastqReader) Alphabet() *seq.Alphabet {
+	return fastqReader.t
+}
@@ -0,0 +1,96 @@
+package fastq
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFastq(t *testing.T, content string) string {
+	t.Helper()
+
+	file := filepath.Join(t.TempDir(), "in.fq")
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func readAll(t *testing.T, file string) []*FastqRecord {
+	t.Helper()
+
+	r, err := NewFastqReader(nil, file, 0, 1, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var records []*FastqRecord
+	for chunk := range r.Ch {
+		if chunk.Err != nil {
+			t.Fatalf("unexpected read error: %s", chunk.Err)
+		}
+		records = append(records, chunk.Data...)
+	}
+	return records
+}
+
+func TestFastqReaderMultiLineRecord(t *testing.T) {
+	// The second record wraps both its sequence and quality across
+	// multiple lines, and the wrapped quality starts with '@' on
+	// purpose: a reader that only reads one seq/+/qual line each would
+	// mistake that line for the start of a new record.
+	file := writeFastq(t, "@r1\nACGT\n+\nIIII\n"+
+		"@r2\nACGT\nACGT\n+\n@@@@\n!!!!\n")
+
+	records := readAll(t, file)
+	if len(records) != 2 {
+		t.Fatalf("got %d records, want 2", len(records))
+	}
+
+	if string(records[0].Seq.Seq) != "ACGT" || string(records[0].Seq.Qual) != "IIII" {
+		t.Errorf("r1 = %q/%q, want %q/%q", records[0].Seq.Seq, records[0].Seq.Qual, "ACGT", "IIII")
+	}
+
+	want := struct{ seq, qual string }{"ACGTACGT", "@@@@!!!!"}
+	if string(records[1].Seq.Seq) != want.seq || string(records[1].Seq.Qual) != want.qual {
+		t.Errorf("r2 = %q/%q, want %q/%q", records[1].Seq.Seq, records[1].Seq.Qual, want.seq, want.qual)
+	}
+}
+
+func TestFastqReaderMissingSeparator(t *testing.T) {
+	file := writeFastq(t, "@r1\nACGT\nIIII\n")
+
+	r, err := NewFastqReader(nil, file, 0, 1, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var gotErr error
+	for chunk := range r.Ch {
+		if chunk.Err != nil {
+			gotErr = chunk.Err
+		}
+	}
+	if gotErr == nil {
+		t.Error("expected a malformed-record error for a record missing its '+' separator")
+	}
+}
+
+func TestFastqReaderUnmatchedLengths(t *testing.T) {
+	file := writeFastq(t, "@r1\nACGT\n+\nII\n")
+
+	r, err := NewFastqReader(nil, file, 0, 1, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var gotErr error
+	for chunk := range r.Ch {
+		if chunk.Err != nil {
+			gotErr = chunk.Err
+		}
+	}
+	if gotErr == nil {
+		t.Error("expected a malformed-record error for mismatched seq/qual lengths")
+	}
+}
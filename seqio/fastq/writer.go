@@ -0,0 +1,49 @@
+package fastq
+
+import (
+	"bufio"
+	"fmt"
+
+	"github.com/brentp/xopen"
+)
+
+// FastqWriter writes FastqRecords to a file. By default it does not
+// wrap sequence/quality lines: unlike FASTA, wrapping FASTQ is
+// ambiguous, since a wrapped quality line may itself start with '+'
+// or '@' and be misread as a new record header by a naive parser.
+type FastqWriter struct {
+	fh *xopen.Writer
+	w  *bufio.Writer
+}
+
+// NewFastqWriter is constructor of FastqWriter. file may be "-" for
+// stdout; a ".gz"/".bz2" suffix selects compressed output via xopen.
+func NewFastqWriter(file string) (*FastqWriter, error) {
+	fh, err := xopen.Wopen(file)
+	if err != nil {
+		return nil, err
+	}
+	return &FastqWriter{fh: fh, w: bufio.NewWriter(fh)}, nil
+}
+
+// WriteRecord writes a single record, unwrapped.
+func (writer *FastqWriter) WriteRecord(record *FastqRecord) error {
+	if _, err := fmt.Fprintf(writer.w, "@%s\n%s\n+\n%s\n",
+		record.Name, record.Seq.Seq, record.Seq.Qual); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Flush flushes buffered output.
+func (writer *FastqWriter) Flush() error {
+	return writer.w.Flush()
+}
+
+// Close flushes and closes the underlying file.
+func (writer *FastqWriter) Close() error {
+	if err := writer.w.Flush(); err != nil {
+		return err
+	}
+	return writer.fh.Close()
+}